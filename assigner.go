@@ -0,0 +1,232 @@
+package yotei
+
+import (
+	"container/heap"
+	"math/rand"
+	"sync"
+)
+
+// Assigner decides which task from a set of candidate tasks
+// should run next.
+//
+// [Scheduler.next] calls [Assigner.Assign] with the unlocked tasks
+// that are under their [TaskType] cap, accepted by their [Selector]
+// for the calling worker and fitting the remaining [Resources], and
+// runs whatever it returns. Returning nil means no task should run
+// right now.
+type Assigner interface {
+	Assign(tasks Tasks) Tasker
+}
+
+// AssignerFunc is a simple type to quickly transform a func
+// into an [Assigner].
+type AssignerFunc func(tasks Tasks) Tasker
+
+// Assign runs the function.
+func (assigner AssignerFunc) Assign(tasks Tasks) Tasker {
+	return assigner(tasks)
+}
+
+// WeightedRandomAssigner picks a task at random, weighted by
+// each task's [Tasker.Weight]. This is the default [Assigner].
+type WeightedRandomAssigner struct{}
+
+// Assign implements [Assigner].
+func (WeightedRandomAssigner) Assign(tasks Tasks) Tasker {
+	weight := tasks.Weight()
+
+	if weight == 0 {
+		return nil
+	}
+
+	pick := rand.Uint64() % weight
+	current := uint64(0)
+
+	for _, task := range tasks {
+		current += task.Weight()
+
+		if pick < current {
+			return task
+		}
+	}
+
+	return nil
+}
+
+// RoundRobinAssigner picks tasks in a fixed rotation, ignoring
+// weight, wrapping back to the start once it reaches the end.
+//
+// The zero value is ready to use.
+type RoundRobinAssigner struct {
+	mutex sync.Mutex
+	index int
+}
+
+// Assign implements [Assigner].
+func (assigner *RoundRobinAssigner) Assign(tasks Tasks) Tasker {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	assigner.mutex.Lock()
+	defer assigner.mutex.Unlock()
+
+	task := tasks[assigner.index%len(tasks)]
+	assigner.index++
+
+	return task
+}
+
+// LowestLatencyAssigner picks the unlocked task configured with
+// the smallest [Tasker.Duration], favoring quick tasks over slow
+// ones. Tasks with [DurationUnlimited] are only picked when no
+// task declares a duration, falling back to [WeightedRandomAssigner].
+type LowestLatencyAssigner struct{}
+
+// Assign implements [Assigner].
+func (LowestLatencyAssigner) Assign(tasks Tasks) Tasker {
+	var lowest Tasker
+
+	for _, task := range tasks {
+		duration := task.Duration()
+
+		if duration == DurationUnlimited {
+			continue
+		}
+
+		if lowest == nil || duration < lowest.Duration() {
+			lowest = task
+		}
+	}
+
+	if lowest != nil {
+		return lowest
+	}
+
+	return WeightedRandomAssigner{}.Assign(tasks)
+}
+
+// PriorityAssigner first partitions tasks by [Tasker.Priority],
+// keeping only those sharing the highest one, then breaks ties
+// among them using Fallback.
+//
+// The zero value falls back to [WeightedRandomAssigner] for
+// tie-breaking.
+type PriorityAssigner struct {
+	// Fallback is used to break ties among tasks sharing the
+	// same priority. Defaults to [WeightedRandomAssigner].
+	Fallback Assigner
+}
+
+// Assign implements [Assigner].
+func (assigner PriorityAssigner) Assign(tasks Tasks) Tasker {
+	fallback := assigner.Fallback
+
+	if fallback == nil {
+		fallback = WeightedRandomAssigner{}
+	}
+
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	highest := tasks[0].Priority()
+
+	for _, task := range tasks[1:] {
+		if priority := task.Priority(); priority > highest {
+			highest = priority
+		}
+	}
+
+	top := make(Tasks, 0, len(tasks))
+
+	for _, task := range tasks {
+		if task.Priority() == highest {
+			top = append(top, task)
+		}
+	}
+
+	return fallback.Assign(top)
+}
+
+// SyncAssigner is implemented by assigners that keep state keyed
+// by task identity, such as [StrideAssigner]'s virtual passes, and
+// therefore need to know when a task leaves the scheduler for good
+// so that state can be released.
+type SyncAssigner interface {
+	Assigner
+
+	// Removed is called by [Scheduler.Remove] with every task
+	// passed to it, whether or not it was in the scheduler.
+	Removed(tasks ...Tasker)
+}
+
+// StrideAssigner picks tasks using stride scheduling: a
+// [container/heap]-backed priority queue ordered by priority and,
+// among tasks sharing a priority, by a virtual pass that advances
+// each time a task is picked, proportionally to 1/[Tasker.Weight].
+// This gives the same fairness as [WeightedRandomAssigner] in
+// expectation, deterministically, without summing weights or
+// rolling a random number on every pick.
+//
+// The heap is rebuilt from the candidate tasks on every [Assign]
+// call rather than kept standing across picks, so this does not
+// change the per-pick complexity versus the weighted scan it
+// replaces; what it buys is deterministic, priority-aware fairness
+// instead of a weighted coin flip. Persisting the heap across picks
+// would need every task's priority change to trigger a [heap.Fix],
+// which nothing currently reports.
+//
+// Since the weight used to advance a task's pass is always read
+// at pick time rather than cached, changing it via [Task.Weights]
+// takes effect on the task's next pick with no extra bookkeeping.
+//
+// The zero value is ready to use.
+type StrideAssigner struct {
+	mutex  sync.Mutex
+	passes map[Tasker]uint64
+}
+
+// Assign implements [Assigner].
+func (assigner *StrideAssigner) Assign(tasks Tasks) Tasker {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	assigner.mutex.Lock()
+	defer assigner.mutex.Unlock()
+
+	if assigner.passes == nil {
+		assigner.passes = make(map[Tasker]uint64)
+	}
+
+	queue := make(strideQueue, 0, len(tasks))
+
+	for _, task := range tasks {
+		queue = append(queue, &strideItem{task: task, pass: assigner.passes[task]})
+	}
+
+	heap.Init(&queue)
+
+	item := heap.Pop(&queue).(*strideItem)
+
+	weight := item.task.Weight()
+
+	if weight == 0 {
+		weight = 1
+	}
+
+	assigner.passes[item.task] = item.pass + maxStride/weight
+
+	return item.task
+}
+
+// Removed implements [SyncAssigner].
+func (assigner *StrideAssigner) Removed(tasks ...Tasker) {
+	assigner.mutex.Lock()
+	defer assigner.mutex.Unlock()
+
+	for _, task := range tasks {
+		delete(assigner.passes, task)
+	}
+}
@@ -0,0 +1,31 @@
+package yotei
+
+import "context"
+
+// DefaultPriority is the priority tasks get when none was set
+// via [Task.Prioritize].
+var DefaultPriority = 0
+
+type priorityContextKey struct{}
+
+// WithPriority returns a copy of ctx carrying the given priority,
+// retrievable later with [PriorityFromContext].
+//
+// [Scheduler] uses this to propagate a task's static priority into
+// the [context.Context] passed to [Handler.Handle], so downstream
+// code (e.g. DB drivers, HTTP calls) can inherit it.
+func WithPriority(ctx context.Context, priority int) context.Context {
+	return context.WithValue(ctx, priorityContextKey{}, priority)
+}
+
+// PriorityFromContext returns the priority stored in ctx by
+// [WithPriority], or [DefaultPriority] if ctx carries none.
+func PriorityFromContext(ctx context.Context) int {
+	priority, ok := ctx.Value(priorityContextKey{}).(int)
+
+	if !ok {
+		return DefaultPriority
+	}
+
+	return priority
+}
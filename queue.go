@@ -0,0 +1,58 @@
+package yotei
+
+import "container/heap"
+
+// maxStride is the numerator used to derive a task's stride from
+// its weight: stride = maxStride / weight. Heavier tasks get a
+// smaller stride, so their virtual pass advances more slowly and
+// they get popped more often, matching [WeightedRandomAssigner]'s
+// fairness in expectation.
+const maxStride = uint64(1) << 32
+
+// strideItem is a single entry of a [strideQueue]: a task along
+// with its current virtual pass.
+type strideItem struct {
+	task  Tasker
+	pass  uint64
+	index int
+}
+
+// strideQueue is a [container/heap] ordering tasks by priority
+// (highest first) and, among tasks sharing a priority, by the
+// lowest virtual pass. It backs [StrideAssigner].
+type strideQueue []*strideItem
+
+func (queue strideQueue) Len() int { return len(queue) }
+
+func (queue strideQueue) Less(i, j int) bool {
+	if pi, pj := queue[i].task.Priority(), queue[j].task.Priority(); pi != pj {
+		return pi > pj
+	}
+
+	return queue[i].pass < queue[j].pass
+}
+
+func (queue strideQueue) Swap(i, j int) {
+	queue[i], queue[j] = queue[j], queue[i]
+	queue[i].index = i
+	queue[j].index = j
+}
+
+func (queue *strideQueue) Push(x any) {
+	item := x.(*strideItem)
+	item.index = len(*queue)
+	*queue = append(*queue, item)
+}
+
+func (queue *strideQueue) Pop() any {
+	old := *queue
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*queue = old[:n-1]
+
+	return item
+}
+
+var _ heap.Interface = (*strideQueue)(nil)
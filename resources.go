@@ -0,0 +1,95 @@
+package yotei
+
+// Resources describes a quota of computational resources. It is
+// used both to declare what a [Task] needs via [Task.Requires],
+// and what a [Scheduler] has available via [Scheduler.Capacity].
+//
+// The zero value requires (or provides) nothing, so tasks that
+// never call [Task.Requires] are never blocked by capacity.
+type Resources struct {
+	// CPU is the number of CPU cores required or available.
+	CPU uint64
+
+	// MemoryMin is the minimum amount of memory, in bytes, a
+	// task needs to run. Unused on a [Scheduler]'s capacity.
+	MemoryMin uint64
+
+	// MemoryMax is the memory, in bytes, available in a
+	// [Scheduler]'s capacity. Unused on a task's requirement,
+	// where only MemoryMin is enforced.
+	MemoryMax uint64
+
+	// GPU is the amount of GPU required or available.
+	GPU float64
+
+	// Custom holds any additional, user-defined quotas.
+	Custom map[string]uint64
+}
+
+// Fits reports whether remaining has enough of each resource to
+// satisfy required.
+func (remaining Resources) Fits(required Resources) bool {
+	if required.CPU > remaining.CPU {
+		return false
+	}
+
+	if required.MemoryMin > remaining.MemoryMax {
+		return false
+	}
+
+	if required.GPU > remaining.GPU {
+		return false
+	}
+
+	for key, value := range required.Custom {
+		if value > remaining.Custom[key] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sub returns remaining with required taken out of it, as done
+// when a task starts running.
+func (remaining Resources) sub(required Resources) Resources {
+	custom := make(map[string]uint64, len(remaining.Custom))
+
+	for key, value := range remaining.Custom {
+		custom[key] = value
+	}
+
+	for key, value := range required.Custom {
+		custom[key] -= value
+	}
+
+	return Resources{
+		CPU:       remaining.CPU - required.CPU,
+		MemoryMin: remaining.MemoryMin,
+		MemoryMax: remaining.MemoryMax - required.MemoryMin,
+		GPU:       remaining.GPU - required.GPU,
+		Custom:    custom,
+	}
+}
+
+// add returns remaining with released given back to it, as done
+// when a task finishes running.
+func (remaining Resources) add(released Resources) Resources {
+	custom := make(map[string]uint64, len(remaining.Custom))
+
+	for key, value := range remaining.Custom {
+		custom[key] = value
+	}
+
+	for key, value := range released.Custom {
+		custom[key] += value
+	}
+
+	return Resources{
+		CPU:       remaining.CPU + released.CPU,
+		MemoryMin: remaining.MemoryMin,
+		MemoryMax: remaining.MemoryMax + released.MemoryMin,
+		GPU:       remaining.GPU + released.GPU,
+		Custom:    custom,
+	}
+}
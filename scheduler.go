@@ -6,7 +6,6 @@ import (
 	"io"
 	"iter"
 	"log/slog"
-	"math/rand"
 	"runtime"
 	"slices"
 	"sync"
@@ -17,13 +16,26 @@ import (
 //
 // Use [NewScheduler] to create a new scheduler.
 type Scheduler struct {
-	workers uint64
-	tasks   Tasks
-	ctx     context.Context
-	cancel  context.CancelFunc
-	wg      sync.WaitGroup
-	logger  *slog.Logger
-	mutex   sync.Mutex
+	workers    uint64
+	tasks      Tasks
+	assigner   Assigner
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+	logger     *slog.Logger
+	mutex      sync.Mutex
+	windowSize uint64
+
+	capacity       Resources
+	remaining      Resources
+	resourcesMutex sync.Mutex
+	resourcesCond  *sync.Cond
+
+	limits       map[TaskType]int
+	taskCounters map[TaskType]int
+	typesMutex   sync.Mutex
+
+	workerLabels map[uint64]map[string]string
 }
 
 // WorkersNumCPUs uses the number of CPU cores of the computer.
@@ -44,20 +56,24 @@ var (
 	SilentLogger *slog.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
 )
 
-// NewScheduler creates a new scheduler with the given workers and logger.
+// NewScheduler creates a new scheduler with the given workers, logger
+// and assigner.
 //
 // If workers is `0` or [WorkersNumCPUs], the number of CPUs in the machine
 // is used, as acording to [runtime.NumCPU].
 //
 // If the logger is `nil` or [DefaultLogger], the [slog.Default] will be used.
 //
+// If the assigner is `nil`, a [WeightedRandomAssigner] is used.
+//
 // # Example
 //
 //	yotei.NewScheduler(
 //		yotei.WorkersNumCPUs,
 //		yotei.DefaultLogger,
+//		yotei.WeightedRandomAssigner{},
 //	)
-func NewScheduler(workers uint64, logger *slog.Logger) *Scheduler {
+func NewScheduler(workers uint64, logger *slog.Logger, assigner Assigner) *Scheduler {
 	if workers == 0 {
 		workers = NumCPUsWorkers
 	}
@@ -66,10 +82,94 @@ func NewScheduler(workers uint64, logger *slog.Logger) *Scheduler {
 		logger = DefaultLogger
 	}
 
-	return &Scheduler{
-		workers: workers,
-		logger:  logger,
+	if assigner == nil {
+		assigner = WeightedRandomAssigner{}
+	}
+
+	scheduler := &Scheduler{
+		workers:    workers,
+		logger:     logger,
+		assigner:   assigner,
+		windowSize: 1,
+	}
+
+	scheduler.resourcesCond = sync.NewCond(&scheduler.resourcesMutex)
+
+	return scheduler
+}
+
+// Capacity sets the total [Resources] pool available to the
+// scheduler. [Scheduler.next] skips tasks whose [Task.Requires]
+// does not fit what currently remains of it.
+//
+// Until Capacity is called the pool is the zero [Resources], which
+// provides nothing: only tasks with no requirements (such as those
+// that never call [Task.Requires]) can run.
+func (scheduler *Scheduler) Capacity(resources Resources) {
+	scheduler.resourcesMutex.Lock()
+	defer scheduler.resourcesMutex.Unlock()
+
+	scheduler.capacity = resources
+	scheduler.remaining = resources
+}
+
+// LimitType caps how many tasks of the given [TaskType] may run at
+// once, regardless of how many such tasks are in the scheduler.
+// [Scheduler.next] skips a task of that type once the cap is
+// reached, until one of the running ones finishes.
+//
+// A negative or zero max is treated as "no tasks of this type may
+// run", not unlimited. Types never passed to LimitType are
+// unlimited.
+func (scheduler *Scheduler) LimitType(taskType TaskType, max int) {
+	scheduler.typesMutex.Lock()
+	defer scheduler.typesMutex.Unlock()
+
+	if scheduler.limits == nil {
+		scheduler.limits = make(map[TaskType]int)
+	}
+
+	scheduler.limits[taskType] = max
+}
+
+// WindowSize sets how many tasks a worker picks in a single call to
+// [Scheduler.next], running them serially before re-entering the
+// scheduler to pick again. Batching picks this way means a worker
+// only needs the scheduler's internal mutex once per window instead
+// of once per task, cutting contention under high task counts and
+// short-lived handlers.
+//
+// A window is also capped to a fair share of what is currently
+// available, ceil(available/[Scheduler.workers]), so one worker
+// calling next with a large window size cannot claim every available
+// task in a single call and starve the others.
+//
+// The zero value (and 1) keep the previous one-task-per-pick
+// behavior.
+func (scheduler *Scheduler) WindowSize(n uint64) {
+	if n == 0 {
+		n = 1
 	}
+
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+
+	scheduler.windowSize = n
+}
+
+// WorkerLabels sets the labels exposed through [WorkerInfo.Labels]
+// for the worker with the given, 0-indexed id (below
+// [Scheduler.workers]). [Selector]s attached to tasks via
+// [Task.Select] use these to decide which workers may run them.
+func (scheduler *Scheduler) WorkerLabels(workerID uint64, labels map[string]string) {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+
+	if scheduler.workerLabels == nil {
+		scheduler.workerLabels = make(map[uint64]map[string]string)
+	}
+
+	scheduler.workerLabels[workerID] = labels
 }
 
 // Add appends a task into the scheduler. If the task
@@ -116,38 +216,110 @@ func (scheduler *Scheduler) Remove(tasks ...Tasker) {
 			}
 		}
 	}
+
+	if syncAssigner, ok := scheduler.assigner.(SyncAssigner); ok {
+		syncAssigner.Removed(tasks...)
+	}
 }
 
-func (scheduler *Scheduler) next() Tasker {
+// sortBySelector orders fitting so that [Scheduler.assigner] sees
+// each task's preferred ordering relative to the others, as reported
+// by that same task's own [Selector.Cmp]. A task without a selector,
+// or a pair where neither side's selector prefers itself over the
+// other, keeps its relative order. Two tasks with different
+// selectors are only ever compared through each one's own Cmp, never
+// through the other's, so one task's selector can't impose an
+// ordering on a task it doesn't belong to.
+func sortBySelector(worker WorkerInfo, fitting Tasks) {
+	slices.SortStableFunc(fitting, func(a, b Tasker) int {
+		if selector := a.Selector(); selector != nil && selector.Cmp(worker, a, b) {
+			return -1
+		}
+
+		if selector := b.Selector(); selector != nil && selector.Cmp(worker, b, a) {
+			return 1
+		}
+
+		return 0
+	})
+}
+
+// next returns up to [Scheduler.windowSize] unlocked, resource-fitting
+// tasks that accept the given worker, picked one at a time through
+// [Scheduler.assigner] without releasing scheduler.mutex in between.
+// This lets a worker fill its whole window with a single lock
+// acquisition instead of one per task.
+func (scheduler *Scheduler) next(workerID uint64) Tasks {
 	if !scheduler.mutex.TryLock() {
 		return nil
 	}
 
 	defer scheduler.mutex.Unlock()
 
-	tasks := scheduler.tasks.Unlocked()
-	weight := tasks.Weight()
+	worker := WorkerInfo{ID: workerID, Labels: scheduler.workerLabels[workerID]}
+	available := scheduler.tasks.Unlocked()
 
-	if weight == 0 {
-		return nil
+	scheduler.typesMutex.Lock()
+	defer scheduler.typesMutex.Unlock()
+
+	limit := scheduler.windowSize
+
+	if scheduler.workers > 1 && len(available) > 0 {
+		if fair := (uint64(len(available)) + scheduler.workers - 1) / scheduler.workers; fair < limit {
+			limit = fair
+		}
 	}
 
-	pick := rand.Uint64() % weight
-	current := uint64(0)
+	window := make(Tasks, 0, limit)
 
-	for _, task := range tasks {
-		current += task.Weight()
+	for uint64(len(window)) < limit {
+		scheduler.resourcesMutex.Lock()
+		remaining := scheduler.remaining
+		scheduler.resourcesMutex.Unlock()
+
+		fitting := make(Tasks, 0, len(available))
+
+		for _, task := range available {
+			if limit, ok := scheduler.limits[task.TaskType()]; ok && scheduler.taskCounters[task.TaskType()] >= limit {
+				continue
+			}
 
-		if pick < current {
-			if !task.IsConcurrent() {
-				task.Lock()
+			if selector := task.Selector(); selector != nil && !selector.Ok(worker, task) {
+				continue
 			}
 
-			return task
+			if remaining.Fits(task.Resources()) {
+				fitting = append(fitting, task)
+			}
 		}
+
+		sortBySelector(worker, fitting)
+
+		task := scheduler.assigner.Assign(fitting)
+
+		if task == nil {
+			break
+		}
+
+		if !task.IsConcurrent() {
+			task.Lock()
+		}
+
+		if scheduler.taskCounters == nil {
+			scheduler.taskCounters = make(map[TaskType]int)
+		}
+
+		scheduler.taskCounters[task.TaskType()]++
+
+		scheduler.resourcesMutex.Lock()
+		scheduler.remaining = scheduler.remaining.sub(task.Resources())
+		scheduler.resourcesMutex.Unlock()
+
+		window = append(window, task)
+		available = available.without(task)
 	}
 
-	return nil
+	return window
 }
 
 func (scheduler *Scheduler) handle(ctx context.Context, task Tasker) {
@@ -161,10 +333,19 @@ func (scheduler *Scheduler) handleTasker(task Tasker) {
 		if !task.IsConcurrent() {
 			task.Unlock()
 		}
+
+		scheduler.typesMutex.Lock()
+		scheduler.taskCounters[task.TaskType()]--
+		scheduler.typesMutex.Unlock()
+
+		scheduler.resourcesMutex.Lock()
+		scheduler.remaining = scheduler.remaining.add(task.Resources())
+		scheduler.resourcesCond.Broadcast()
+		scheduler.resourcesMutex.Unlock()
 	}()
 
 	if duration := task.Duration(); duration > 0 {
-		ctx, cancel := context.WithTimeout(scheduler.ctx, duration)
+		ctx, cancel := context.WithTimeout(WithPriority(scheduler.ctx, task.Priority()), duration)
 		defer cancel()
 
 		go scheduler.handle(ctx, task)
@@ -174,10 +355,10 @@ func (scheduler *Scheduler) handleTasker(task Tasker) {
 		return
 	}
 
-	scheduler.handle(context.Background(), task)
+	scheduler.handle(WithPriority(context.Background(), task.Priority()), task)
 }
 
-func (scheduler *Scheduler) worker() {
+func (scheduler *Scheduler) worker(id uint64) {
 	defer scheduler.wg.Done()
 
 	for {
@@ -185,10 +366,58 @@ func (scheduler *Scheduler) worker() {
 		case <-scheduler.ctx.Done():
 			return
 		default:
-			if task := scheduler.next(); task != nil {
-				scheduler.handleTasker(task)
+			window := scheduler.next(id)
+
+			if len(window) == 0 {
+				scheduler.waitForCapacity()
 				continue
 			}
+
+			// Every task here was already pulled out of the
+			// scheduler by next, locking it if it's not
+			// concurrent. Only handleTasker's deferred cleanup
+			// unlocks it, decrements taskCounters and releases
+			// its Resources, so each one must run to completion
+			// even if ctx is cancelled mid-window; the ctx.Done
+			// check above only skips starting a *new* window.
+			for _, task := range window {
+				scheduler.handleTasker(task)
+			}
+		}
+	}
+}
+
+// waitForCapacity blocks the calling worker until resources are
+// released back to the pool, but only if there currently is at
+// least one unlocked task "starved" for capacity. Otherwise it
+// returns immediately, leaving the worker to keep re-evaluating
+// [Scheduler.next] as before resource-awareness was introduced.
+//
+// It re-checks scheduler.ctx before every [sync.Cond.Wait], not just
+// after waking from one: [Scheduler.Stop] cancels the context and
+// broadcasts exactly once, and that broadcast only wakes goroutines
+// already parked in Wait. A worker that reaches here after the
+// broadcast already fired would otherwise Wait forever. Both that
+// broadcast and this check happen under resourcesMutex, so whichever
+// runs first is visible to the other: either the cancellation is
+// already observable here and we skip Wait entirely, or we start
+// waiting before the broadcast and it wakes us as normal.
+func (scheduler *Scheduler) waitForCapacity() {
+	scheduler.mutex.Lock()
+	tasks := scheduler.tasks.Unlocked()
+	ctx := scheduler.ctx
+	scheduler.mutex.Unlock()
+
+	scheduler.resourcesMutex.Lock()
+	defer scheduler.resourcesMutex.Unlock()
+
+	for _, task := range tasks {
+		if !scheduler.remaining.Fits(task.Resources()) {
+			for ctx.Err() == nil && !scheduler.remaining.Fits(task.Resources()) {
+				scheduler.resourcesCond.Wait()
+			}
+
+			return
 		}
 	}
 }
@@ -225,7 +454,7 @@ func (scheduler *Scheduler) Start() {
 
 	for i := uint64(0); i < scheduler.workers; i++ {
 		scheduler.wg.Add(1)
-		go scheduler.worker()
+		go scheduler.worker(i)
 	}
 }
 
@@ -243,15 +472,32 @@ func (scheduler *Scheduler) Stop() {
 	}
 
 	scheduler.mutex.Lock()
-	defer scheduler.mutex.Unlock()
-
 	scheduler.logger.Info("stopping scheduler")
-
 	scheduler.cancel()
+	scheduler.mutex.Unlock()
+
+	scheduler.resourcesMutex.Lock()
+	scheduler.resourcesCond.Broadcast()
+	scheduler.resourcesMutex.Unlock()
+
+	// wg.Wait is intentionally outside the mutex above: workers may
+	// still need scheduler.mutex (e.g. [Scheduler.waitForCapacity])
+	// to notice ctx is done and exit.
 	scheduler.wg.Wait()
+
+	scheduler.mutex.Lock()
 	scheduler.tasks = nil
 	scheduler.ctx = nil
 	scheduler.cancel = nil
+	scheduler.mutex.Unlock()
+
+	scheduler.typesMutex.Lock()
+	scheduler.taskCounters = nil
+	scheduler.typesMutex.Unlock()
+
+	scheduler.resourcesMutex.Lock()
+	scheduler.remaining = scheduler.capacity
+	scheduler.resourcesMutex.Unlock()
 }
 
 // IsRunning determines if the scheduler is
@@ -275,6 +521,30 @@ func (scheduler *Scheduler) Snapshot() iter.Seq[Tasker] {
 	return slices.Values(slices.Clone(scheduler.tasks))
 }
 
+// Blocked returns the currently unlocked tasks that do not fit
+// the scheduler's remaining resource capacity ("starved"), as
+// opposed to tasks whose sequential lock is held ("locked",
+// see [Tasks.Locked]).
+func (scheduler *Scheduler) Blocked() iter.Seq[Tasker] {
+	scheduler.mutex.Lock()
+	tasks := scheduler.tasks.Unlocked()
+	scheduler.mutex.Unlock()
+
+	scheduler.resourcesMutex.Lock()
+	remaining := scheduler.remaining
+	scheduler.resourcesMutex.Unlock()
+
+	starved := make(Tasks, 0)
+
+	for _, task := range tasks {
+		if !remaining.Fits(task.Resources()) {
+			starved = append(starved, task)
+		}
+	}
+
+	return slices.Values(starved)
+}
+
 // String returns a string representation of a scheduler.
 func (scheduler *Scheduler) String() string {
 	scheduler.mutex.Lock()
@@ -0,0 +1,85 @@
+package yotei
+
+// WorkerInfo identifies a single worker goroutine to a [Selector]: its
+// stable, 0-indexed ID among [Scheduler.workers], and whatever labels
+// were last set for it with [Scheduler.WorkerLabels].
+type WorkerInfo struct {
+	ID     uint64
+	Labels map[string]string
+}
+
+// Selector constrains which workers may run a task, attached via
+// [Task.Select]. [Scheduler.next] skips a task for a worker that Ok
+// rejects, and uses Cmp to order the tasks Ok allows on the same
+// worker before handing them to the scheduler's [Assigner]. Whether
+// that order changes the pick depends on the assigner:
+// [WeightedRandomAssigner] ignores it.
+type Selector interface {
+	// Ok reports whether task may run on worker.
+	Ok(worker WorkerInfo, task Tasker) bool
+
+	// Cmp reports whether a is preferred over b on worker. Only
+	// consulted to order tasks Ok already allows.
+	Cmp(worker WorkerInfo, a, b Tasker) bool
+}
+
+// LabelSelector only allows workers whose [WorkerInfo.Labels] contain
+// every key and value of the selector.
+type LabelSelector map[string]string
+
+// Ok implements [Selector].
+func (selector LabelSelector) Ok(worker WorkerInfo, task Tasker) bool {
+	for key, value := range selector {
+		if worker.Labels[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Cmp implements [Selector]. A LabelSelector has no preference
+// between tasks it both allows.
+func (selector LabelSelector) Cmp(worker WorkerInfo, a, b Tasker) bool {
+	return false
+}
+
+// AnySelector allows a worker the moment one of its selectors does.
+//
+// An empty AnySelector allows no worker; see [NotSelector] to invert
+// a selector instead.
+type AnySelector []Selector
+
+// Ok implements [Selector].
+func (selector AnySelector) Ok(worker WorkerInfo, task Tasker) bool {
+	for _, s := range selector {
+		if s.Ok(worker, task) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Cmp implements [Selector]. An AnySelector has no preference
+// between tasks it both allows.
+func (selector AnySelector) Cmp(worker WorkerInfo, a, b Tasker) bool {
+	return false
+}
+
+// NotSelector allows a worker exactly when the wrapped [Selector]
+// does not.
+type NotSelector struct {
+	Selector Selector
+}
+
+// Ok implements [Selector].
+func (selector NotSelector) Ok(worker WorkerInfo, task Tasker) bool {
+	return !selector.Selector.Ok(worker, task)
+}
+
+// Cmp implements [Selector]. A NotSelector has no preference between
+// tasks it both allows.
+func (selector NotSelector) Cmp(worker WorkerInfo, a, b Tasker) bool {
+	return false
+}
@@ -14,6 +14,10 @@ type Task struct {
 	handler    Handler
 	weight     atomic.Uint64
 	duration   atomic.Int64
+	priority   atomic.Int64
+	requires   atomic.Pointer[Resources]
+	taskType   atomic.Pointer[TaskType]
+	selector   atomic.Pointer[Selector]
 	locked     atomic.Bool
 	concurrent atomic.Bool
 }
@@ -30,6 +34,7 @@ func NewTask(handler Handler) *Task {
 
 	task.weight.Store(1)
 	task.duration.Store(int64(DurationUnlimited))
+	task.priority.Store(int64(DefaultPriority))
 	task.concurrent.Store(false)
 
 	return task
@@ -77,6 +82,75 @@ func (task *Task) Weight() uint64 {
 	return task.weight.Load()
 }
 
+// Prioritize sets the static priority of the task. Higher values
+// run first; see [PriorityAssigner].
+func (task *Task) Prioritize(priority int) *Task {
+	task.priority.Store(int64(priority))
+
+	return task
+}
+
+// Priority returns the task's static priority.
+func (task *Task) Priority() int {
+	return int(task.priority.Load())
+}
+
+// Requires sets the [Resources] the task needs to run. The
+// scheduler skips the task whenever these do not fit its
+// remaining capacity; see [Scheduler.Capacity].
+func (task *Task) Requires(resources Resources) *Task {
+	task.requires.Store(&resources)
+
+	return task
+}
+
+// Resources returns the task's resource requirements. Defaults
+// to the zero [Resources], which always fits.
+func (task *Task) Resources() Resources {
+	if resources := task.requires.Load(); resources != nil {
+		return *resources
+	}
+
+	return Resources{}
+}
+
+// Type sets the task's [TaskType], used by [Scheduler.LimitType]
+// to cap how many tasks of that type may run at once.
+func (task *Task) Type(taskType TaskType) *Task {
+	task.taskType.Store(&taskType)
+
+	return task
+}
+
+// TaskType returns the task's [TaskType]. Defaults to the empty
+// string, which [Scheduler.LimitType] can cap just like any other.
+func (task *Task) TaskType() TaskType {
+	if taskType := task.taskType.Load(); taskType != nil {
+		return *taskType
+	}
+
+	return ""
+}
+
+// Select attaches a [Selector] that constrains which workers may run
+// the task. [Scheduler.next] skips a task for a worker its selector
+// rejects; see [Scheduler.WorkerLabels].
+func (task *Task) Select(selector Selector) *Task {
+	task.selector.Store(&selector)
+
+	return task
+}
+
+// Selector returns the task's [Selector]. Defaults to nil, meaning
+// the task may run on any worker.
+func (task *Task) Selector() Selector {
+	if selector := task.selector.Load(); selector != nil {
+		return *selector
+	}
+
+	return nil
+}
+
 func (task *Task) Handle(ctx context.Context) Action {
 	if task.handler == nil {
 		panic("no task handler defined. please ensure the task handler is not nil")
@@ -88,9 +162,13 @@ func (task *Task) Handle(ctx context.Context) Action {
 // String returns a string representation of a task.
 func (task *Task) String() string {
 	return fmt.Sprintf(
-		"Task{weight=%d, duration=%s, is_concurrent=%t, is_locked=%t}",
+		"Task{weight=%d, duration=%s, priority=%d, resources=%+v, type=%q, has_selector=%t, is_concurrent=%t, is_locked=%t}",
 		task.Weight(),
 		task.Duration(),
+		task.Priority(),
+		task.Resources(),
+		task.TaskType(),
+		task.Selector() != nil,
 		task.IsConcurrent(),
 		task.IsLocked(),
 	)
@@ -8,6 +8,10 @@ type Tasker interface {
 	Handler
 	Duration() time.Duration
 	Weight() uint64
+	Priority() int
+	Resources() Resources
+	TaskType() TaskType
+	Selector() Selector
 	Lock()
 	Unlock()
 	IsLocked() bool
@@ -57,3 +61,16 @@ func (tasks Tasks) Locked() Tasks {
 
 	return locked
 }
+
+// without returns the tasks excluding the given one.
+func (tasks Tasks) without(excluded Tasker) Tasks {
+	remaining := make(Tasks, 0, len(tasks))
+
+	for _, task := range tasks {
+		if task != excluded {
+			remaining = append(remaining, task)
+		}
+	}
+
+	return remaining
+}
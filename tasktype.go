@@ -0,0 +1,6 @@
+package yotei
+
+// TaskType identifies a class of tasks, used to cap how many of
+// them may run at once regardless of how many exist in the
+// scheduler; see [Task.Type] and [Scheduler.LimitType].
+type TaskType string
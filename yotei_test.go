@@ -23,8 +23,9 @@ func (counter *CounterHandler) Count() uint64 {
 
 func TestThreeTasks(t *testing.T) {
 	scheduler := yotei.NewScheduler(
-		yotei.WorkersNumCPUs,
+		yotei.NumCPUsWorkers,
 		yotei.DefaultLogger,
+		nil,
 	)
 
 	counter1 := &CounterHandler{}
@@ -76,6 +77,7 @@ func TestItDoesNotRunLockedTasks(t *testing.T) {
 	scheduler := yotei.NewScheduler(
 		12,
 		yotei.DefaultLogger,
+		nil,
 	)
 
 	counter1 := &CounterHandler{}
@@ -113,8 +115,9 @@ func TestItDoesNotRunLockedTasks(t *testing.T) {
 
 func TestSequence(t *testing.T) {
 	scheduler := yotei.NewScheduler(
-		yotei.WorkersNumCPUs,
+		yotei.NumCPUsWorkers,
 		yotei.DefaultLogger,
+		nil,
 	)
 
 	calls := make([]string, 0)
@@ -164,3 +167,356 @@ func TestSequence(t *testing.T) {
 		t.Fatalf("expected calls[2]=%s but got %s", expected, calls[2])
 	}
 }
+
+func TestPriorityAssignerPrefersHighestPriority(t *testing.T) {
+	scheduler := yotei.NewScheduler(
+		yotei.SingleWorker,
+		yotei.DefaultLogger,
+		yotei.PriorityAssigner{},
+	)
+
+	counterLow := &CounterHandler{}
+	counterHigh := &CounterHandler{}
+
+	tasks := yotei.Tasks{
+		yotei.
+			NewTask(counterLow).
+			Concurrent(true).
+			Prioritize(0),
+		yotei.
+			NewTask(counterHigh).
+			Concurrent(true).
+			Prioritize(10),
+	}
+
+	scheduler.Add(tasks...)
+	scheduler.Start()
+	time.Sleep(2 * time.Millisecond)
+	scheduler.Stop()
+
+	t.Log(tasks[0], "->", counterLow.Count())
+	t.Log(tasks[1], "->", counterHigh.Count())
+
+	if counterLow.Count() > 0 {
+		t.Fatalf("expected the lower priority task not to run, but it ran %d times", counterLow.Count())
+	}
+
+	if counterHigh.Count() == 0 {
+		t.Fatalf("expected the higher priority task to run")
+	}
+}
+
+func TestPriorityPropagatedToContext(t *testing.T) {
+	scheduler := yotei.NewScheduler(
+		yotei.SingleWorker,
+		yotei.DefaultLogger,
+		nil,
+	)
+
+	seen := make(chan int, 1)
+
+	var handler yotei.HandlerFunc = func(ctx context.Context) yotei.Action {
+		seen <- yotei.PriorityFromContext(ctx)
+
+		return yotei.Done()
+	}
+
+	task := yotei.NewTask(handler).Prioritize(7)
+
+	scheduler.Add(task)
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	select {
+	case priority := <-seen:
+		if expected := 7; priority != expected {
+			t.Fatalf("expected priority=%d but got %d", expected, priority)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("handler was never called")
+	}
+}
+
+func TestStrideAssignerRespectsWeight(t *testing.T) {
+	scheduler := yotei.NewScheduler(
+		yotei.NumCPUsWorkers,
+		yotei.DefaultLogger,
+		&yotei.StrideAssigner{},
+	)
+
+	counter1 := &CounterHandler{}
+	counter2 := &CounterHandler{}
+	counter3 := &CounterHandler{}
+
+	tasks := yotei.Tasks{
+		yotei.
+			NewTask(counter1).
+			Weights(10).
+			Concurrent(true),
+		yotei.
+			NewTask(counter2).
+			Weights(20).
+			Concurrent(true),
+		yotei.
+			NewTask(counter3).
+			Weights(30).
+			Concurrent(true),
+	}
+
+	scheduler.Add(tasks...)
+	scheduler.Start()
+	time.Sleep(2 * time.Millisecond)
+	scheduler.Stop()
+
+	t.Log(tasks[0], "->", counter1.Count())
+	t.Log(tasks[1], "->", counter2.Count())
+	t.Log(tasks[2], "->", counter3.Count())
+
+	if counter1.Count() > counter2.Count() {
+		t.Fatalf(
+			"counter1=%d should not be higher than counter2=%d",
+			counter1.Count(),
+			counter2.Count(),
+		)
+	}
+
+	if counter2.Count() > counter3.Count() {
+		t.Fatalf(
+			"counter2=%d should not be higher than counter3=%d",
+			counter2.Count(),
+			counter3.Count(),
+		)
+	}
+}
+
+func TestCapacityBlocksStarvedTasks(t *testing.T) {
+	scheduler := yotei.NewScheduler(
+		yotei.NumCPUsWorkers,
+		yotei.DefaultLogger,
+		nil,
+	)
+
+	scheduler.Capacity(yotei.Resources{CPU: 1})
+
+	counter := &CounterHandler{}
+
+	task := yotei.
+		NewTask(counter).
+		Concurrent(true).
+		Requires(yotei.Resources{CPU: 2})
+
+	scheduler.Add(task)
+	scheduler.Start()
+	time.Sleep(2 * time.Millisecond)
+
+	blocked := 0
+	for range scheduler.Blocked() {
+		blocked++
+	}
+
+	scheduler.Stop()
+
+	if counter.Count() != 0 {
+		t.Fatalf("expected the task to never run, but it ran %d times", counter.Count())
+	}
+
+	if expected := 1; blocked != expected {
+		t.Fatalf("expected blocked=%d but got %d", expected, blocked)
+	}
+}
+
+func TestLimitTypeCapsConcurrency(t *testing.T) {
+	scheduler := yotei.NewScheduler(
+		yotei.NumCPUsWorkers,
+		yotei.DefaultLogger,
+		nil,
+	)
+
+	scheduler.LimitType("download", 1)
+
+	var current atomic.Int64
+	var peak atomic.Int64
+
+	var handler yotei.HandlerFunc = func(context.Context) yotei.Action {
+		if now := current.Add(1); now > peak.Load() {
+			peak.Store(now)
+		}
+
+		time.Sleep(2 * time.Millisecond)
+		current.Add(-1)
+
+		return yotei.Continue()
+	}
+
+	tasks := yotei.Tasks{
+		yotei.NewTask(handler).Concurrent(true).Type("download"),
+		yotei.NewTask(handler).Concurrent(true).Type("download"),
+		yotei.NewTask(handler).Concurrent(true).Type("download"),
+	}
+
+	scheduler.Add(tasks...)
+	scheduler.Start()
+	time.Sleep(20 * time.Millisecond)
+	scheduler.Stop()
+
+	if expected := int64(1); peak.Load() > expected {
+		t.Fatalf("expected peak=%d but got %d", expected, peak.Load())
+	}
+}
+
+func TestWindowSizeDoesNotStarveOtherWorkers(t *testing.T) {
+	scheduler := yotei.NewScheduler(
+		3,
+		yotei.DefaultLogger,
+		nil,
+	)
+
+	scheduler.WindowSize(10)
+
+	const sleep = 15 * time.Millisecond
+
+	var running atomic.Int64
+	var peak atomic.Int64
+
+	var handler yotei.HandlerFunc = func(context.Context) yotei.Action {
+		if now := running.Add(1); now > peak.Load() {
+			peak.Store(now)
+		}
+
+		time.Sleep(sleep)
+		running.Add(-1)
+
+		return yotei.Done()
+	}
+
+	tasks := make(yotei.Tasks, 6)
+	for i := range tasks {
+		tasks[i] = yotei.NewTask(handler)
+	}
+
+	scheduler.Add(tasks...)
+	scheduler.Start()
+	time.Sleep(sleep / 2)
+	scheduler.Stop()
+
+	if expected := int64(2); peak.Load() < expected {
+		t.Fatalf(
+			"expected at least %d tasks running at once across workers, but peak was %d",
+			expected,
+			peak.Load(),
+		)
+	}
+}
+
+func TestWindowSizeRunsAllTasks(t *testing.T) {
+	scheduler := yotei.NewScheduler(
+		yotei.SingleWorker,
+		yotei.DefaultLogger,
+		nil,
+	)
+
+	scheduler.WindowSize(2)
+
+	counter1 := &CounterHandler{}
+	counter2 := &CounterHandler{}
+	counter3 := &CounterHandler{}
+
+	tasks := yotei.Tasks{
+		yotei.NewTask(counter1).Concurrent(true),
+		yotei.NewTask(counter2).Concurrent(true),
+		yotei.NewTask(counter3).Concurrent(true),
+	}
+
+	scheduler.Add(tasks...)
+	scheduler.Start()
+	time.Sleep(2 * time.Millisecond)
+	scheduler.Stop()
+
+	if counter1.Count() == 0 || counter2.Count() == 0 || counter3.Count() == 0 {
+		t.Fatalf(
+			"expected every task to run at least once, got %d, %d, %d",
+			counter1.Count(),
+			counter2.Count(),
+			counter3.Count(),
+		)
+	}
+}
+
+func TestSelectorPinsTaskToLabeledWorker(t *testing.T) {
+	scheduler := yotei.NewScheduler(
+		2,
+		yotei.DefaultLogger,
+		nil,
+	)
+
+	scheduler.WorkerLabels(0, map[string]string{"gpu": "true"})
+	scheduler.WorkerLabels(1, map[string]string{"gpu": "false"})
+
+	counter := &CounterHandler{}
+
+	task := yotei.
+		NewTask(counter).
+		Concurrent(true).
+		Select(yotei.LabelSelector{"gpu": "true"})
+
+	scheduler.Add(task)
+	scheduler.Start()
+	time.Sleep(50 * time.Millisecond)
+	scheduler.Stop()
+
+	if counter.Count() == 0 {
+		t.Fatal("expected the task to run on the gpu-labeled worker")
+	}
+}
+
+func TestNotSelectorExcludesLabeledWorker(t *testing.T) {
+	scheduler := yotei.NewScheduler(
+		1,
+		yotei.DefaultLogger,
+		nil,
+	)
+
+	scheduler.WorkerLabels(0, map[string]string{"gpu": "true"})
+
+	counter := &CounterHandler{}
+
+	task := yotei.
+		NewTask(counter).
+		Concurrent(true).
+		Select(yotei.NotSelector{Selector: yotei.LabelSelector{"gpu": "true"}})
+
+	scheduler.Add(task)
+	scheduler.Start()
+	time.Sleep(10 * time.Millisecond)
+	scheduler.Stop()
+
+	if counter.Count() != 0 {
+		t.Fatalf("expected the task never to run on the excluded worker, but it ran %d times", counter.Count())
+	}
+}
+
+func TestCapacityReleasedAfterCompletion(t *testing.T) {
+	scheduler := yotei.NewScheduler(
+		yotei.NumCPUsWorkers,
+		yotei.DefaultLogger,
+		nil,
+	)
+
+	scheduler.Capacity(yotei.Resources{CPU: 1})
+
+	counter := &CounterHandler{}
+
+	task := yotei.
+		NewTask(counter).
+		Concurrent(true).
+		Requires(yotei.Resources{CPU: 1})
+
+	scheduler.Add(task)
+	scheduler.Start()
+	time.Sleep(2 * time.Millisecond)
+	scheduler.Stop()
+
+	if counter.Count() < 2 {
+		t.Fatalf("expected the task to run again once its capacity was released, but it ran %d times", counter.Count())
+	}
+}